@@ -0,0 +1,102 @@
+// Package budget membagi sisa waktu sebuah context ke beberapa langkah
+// berurutan (misalnya DB → cache → API eksternal) dan mencatat
+// berapa lama setiap langkah berjalan.
+package budget
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Step adalah catatan satu langkah dalam budget
+type Step struct {
+	// Name adalah nama langkah yang diberikan ke StepContext
+	Name string
+
+	// Allotted adalah waktu yang dialokasikan untuk langkah ini.
+	// Nilai 0 berarti parent tidak memiliki deadline
+	Allotted time.Duration
+
+	// Elapsed adalah lama langkah berjalan sampai cancel dipanggil,
+	// atau sampai Report dipanggil jika langkah belum selesai
+	Elapsed time.Duration
+
+	// Err adalah error context langkah saat cancel dipanggil,
+	// misalnya context.DeadlineExceeded jika langkah melewati jatahnya
+	Err error
+
+	// Done bernilai true setelah cancel langkah dipanggil
+	Done bool
+
+	start time.Time
+}
+
+// Budget membagi sisa deadline parent ke beberapa langkah
+type Budget struct {
+	parent context.Context
+
+	mu    sync.Mutex
+	steps []*Step
+}
+
+// New membuat budget dari sisa deadline ctx
+func New(ctx context.Context) *Budget {
+	return &Budget{parent: ctx}
+}
+
+// StepContext membuat context untuk satu langkah dengan deadline sebesar
+// fraction dari sisa waktu parent saat ini. Karena dihitung dari sisa waktu,
+// langkah yang selesai lebih cepat otomatis menyisakan waktu lebih banyak
+// untuk langkah berikutnya. Fraction di luar (0, 1] dianggap 1.
+// Best practice: Panggil cancel segera setelah langkah selesai,
+// karena saat itulah waktu langkah dicatat
+func (budget *Budget) StepContext(name string, fraction float64) (context.Context, context.CancelFunc) {
+	if fraction <= 0 || fraction > 1 {
+		fraction = 1
+	}
+
+	step := &Step{Name: name, start: time.Now()}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if deadline, ok := budget.parent.Deadline(); ok {
+		// Deadline parent yang sudah lewat menghasilkan jatah 0, bukan negatif
+		step.Allotted = max(time.Duration(float64(deadline.Sub(step.start))*fraction), 0)
+		ctx, cancel = context.WithTimeout(budget.parent, step.Allotted)
+	} else {
+		ctx, cancel = context.WithCancel(budget.parent)
+	}
+
+	budget.mu.Lock()
+	budget.steps = append(budget.steps, step)
+	budget.mu.Unlock()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			budget.mu.Lock()
+			step.Elapsed = time.Since(step.start)
+			step.Err = ctx.Err()
+			step.Done = true
+			budget.mu.Unlock()
+
+			cancel()
+		})
+	}
+}
+
+// Report mengembalikan salinan catatan semua langkah sesuai urutan pembuatannya
+func (budget *Budget) Report() []Step {
+	budget.mu.Lock()
+	defer budget.mu.Unlock()
+
+	report := make([]Step, len(budget.steps))
+	for i, step := range budget.steps {
+		report[i] = *step
+		if !step.Done {
+			report[i].Elapsed = time.Since(step.start)
+		}
+	}
+	return report
+}
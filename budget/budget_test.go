@@ -0,0 +1,93 @@
+package budget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestBudget membagi satu timeout ke tiga langkah berurutan
+// dan memeriksa deadline serta catatan setiap langkah
+func TestBudget(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	b := New(parent)
+
+	// Langkah database mendapat setengah dari sisa waktu
+	dbCtx, dbCancel := b.StepContext("database", 0.5)
+	dbDeadline, _ := dbCtx.Deadline()
+	if remaining := time.Until(dbDeadline); remaining > 510*time.Millisecond || remaining < 400*time.Millisecond {
+		t.Errorf("expected about 500ms for database, got %v", remaining)
+	}
+	time.Sleep(20 * time.Millisecond)
+	dbCancel()
+
+	// Langkah cache melewati jatahnya
+	cacheCtx, cacheCancel := b.StepContext("cache", 0.01)
+	<-cacheCtx.Done()
+	cacheCancel()
+
+	// Langkah terakhir memakai seluruh sisa waktu, sama dengan deadline parent
+	apiCtx, apiCancel := b.StepContext("api", 1)
+	apiDeadline, _ := apiCtx.Deadline()
+	parentDeadline, _ := parent.Deadline()
+	if !apiDeadline.Equal(parentDeadline) {
+		t.Errorf("expected api deadline %v, got %v", parentDeadline, apiDeadline)
+	}
+
+	report := b.Report()
+	for _, step := range report {
+		fmt.Printf("%s: jatah %s, berjalan %s, done %v, err %v\n", step.Name, step.Allotted, step.Elapsed, step.Done, step.Err)
+	}
+	apiCancel()
+
+	if len(report) != 3 {
+		t.Fatalf("expected 3 steps, got %d", len(report))
+	}
+	if report[0].Name != "database" || report[0].Err != nil || report[0].Elapsed < 20*time.Millisecond {
+		t.Errorf("unexpected database step %+v", report[0])
+	}
+	if !errors.Is(report[1].Err, context.DeadlineExceeded) {
+		t.Errorf("expected cache step to exceed its deadline, got %v", report[1].Err)
+	}
+	if report[2].Done {
+		t.Error("expected api step to still be running when reported")
+	}
+}
+
+// TestBudgetWithoutDeadline memastikan parent tanpa deadline
+// menghasilkan langkah tanpa deadline
+func TestBudgetWithoutDeadline(t *testing.T) {
+	b := New(context.Background())
+
+	ctx, cancel := b.StepContext("tanpa-deadline", 0.5)
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline")
+	}
+	cancel()
+
+	if report := b.Report(); report[0].Allotted != 0 || !report[0].Done {
+		t.Errorf("unexpected step %+v", report[0])
+	}
+}
+
+// TestBudgetExpiredParent memastikan parent yang deadline-nya sudah lewat
+// menghasilkan jatah 0 dan context langkah yang langsung selesai
+func TestBudgetExpiredParent(t *testing.T) {
+	parent, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	b := New(parent)
+	ctx, stepCancel := b.StepContext("terlambat", 0.5)
+	stepCancel()
+
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", ctx.Err())
+	}
+	if report := b.Report(); report[0].Allotted != 0 {
+		t.Errorf("expected zero allotment, got %v", report[0].Allotted)
+	}
+}
@@ -0,0 +1,101 @@
+package belajar_golang_context
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrChaos adalah cause yang dipasang pada context yang dibatalkan oleh WithChaos.
+// Gunakan context.Cause(ctx) untuk membedakannya dari pembatalan biasa
+var ErrChaos = errors.New("context dibatalkan oleh chaos")
+
+// ChaosConfig mengatur kapan WithChaos membatalkan context secara acak
+type ChaosConfig struct {
+	// CancelProbability adalah peluang (0.0 - 1.0) context akan dibatalkan
+	CancelProbability float64
+
+	// MinDelay dan MaxDelay adalah rentang waktu sebelum pembatalan terjadi
+	MinDelay time.Duration
+	MaxDelay time.Duration
+
+	// Seed membuat urutan keputusan chaos bisa diulang. Nilai 0 berarti acak
+	Seed uint64
+}
+
+// Chaos menyimpan satu sumber acak untuk sebuah ChaosConfig, sehingga setiap
+// context yang dibuat darinya mendapat keputusan dan delay yang berbeda,
+// tetapi urutannya selalu sama untuk seed yang sama.
+// Aman digunakan oleh banyak goroutine sekaligus
+type Chaos struct {
+	config ChaosConfig
+
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+// NewChaos membuat Chaos dengan sumber acak sendiri
+func NewChaos(config ChaosConfig) *Chaos {
+	seed1, seed2 := config.Seed, config.Seed
+	if config.Seed == 0 {
+		seed1, seed2 = rand.Uint64(), rand.Uint64()
+	}
+	return &Chaos{
+		config: config,
+		random: rand.New(rand.NewPCG(seed1, seed2)),
+	}
+}
+
+// decide mengambil keputusan berikutnya dari urutan acak:
+// apakah context dibatalkan, dan setelah berapa lama
+func (chaos *Chaos) decide() (bool, time.Duration) {
+	chaos.mu.Lock()
+	defer chaos.mu.Unlock()
+
+	if chaos.random.Float64() >= chaos.config.CancelProbability {
+		return false, 0
+	}
+
+	delay := chaos.config.MinDelay
+	if chaos.config.MaxDelay > chaos.config.MinDelay {
+		delay += time.Duration(chaos.random.Int64N(int64(chaos.config.MaxDelay - chaos.config.MinDelay)))
+	}
+	return true, delay
+}
+
+// WithChaos membuat context turunan yang, dengan peluang CancelProbability,
+// dibatalkan secara otomatis setelah jeda acak antara MinDelay dan MaxDelay.
+// Berguna untuk menguji apakah pipeline, pool, atau consumer benar-benar
+// tahan terhadap pembatalan di titik yang tidak terduga.
+// Best practice: Gunakan hanya di test, dan selalu panggil cancel dengan defer
+func (chaos *Chaos) WithChaos(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+
+	shouldCancel, delay := chaos.decide()
+	if !shouldCancel {
+		return ctx, func() { cancel(context.Canceled) }
+	}
+
+	timer := time.AfterFunc(delay, func() {
+		cancel(ErrChaos)
+	})
+
+	// Menghentikan timer ketika context selesai lebih dulu,
+	// baik karena parent dibatalkan maupun cancel dipanggil
+	context.AfterFunc(ctx, func() {
+		timer.Stop()
+	})
+
+	return ctx, func() { cancel(context.Canceled) }
+}
+
+// WithChaos adalah bentuk sekali pakai dari NewChaos(config).WithChaos(parent).
+// Dengan Seed, keputusan untuk context ini bisa diulang, tetapi setiap pemanggilan
+// memulai urutan dari awal sehingga selalu mendapat keputusan yang sama.
+// Best practice: Gunakan NewChaos dan panggil method WithChaos berulang kali
+// jika membutuhkan urutan keputusan yang bervariasi
+func WithChaos(parent context.Context, config ChaosConfig) (context.Context, context.CancelFunc) {
+	return NewChaos(config).WithChaos(parent)
+}
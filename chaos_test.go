@@ -0,0 +1,108 @@
+package belajar_golang_context
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestWithChaos memastikan context dengan peluang 1.0 selalu dibatalkan
+// dengan cause ErrChaos dalam rentang delay yang ditentukan
+func TestWithChaos(t *testing.T) {
+	ctx, cancel := WithChaos(context.Background(), ChaosConfig{
+		CancelProbability: 1,
+		MinDelay:          10 * time.Millisecond,
+		MaxDelay:          50 * time.Millisecond,
+	})
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected chaos cancellation")
+	}
+
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", ctx.Err())
+	}
+	if !errors.Is(context.Cause(ctx), ErrChaos) {
+		t.Errorf("expected ErrChaos cause, got %v", context.Cause(ctx))
+	}
+}
+
+// TestWithChaosNeverCancel memastikan peluang 0 tidak pernah membatalkan context
+func TestWithChaosNeverCancel(t *testing.T) {
+	ctx, cancel := WithChaos(context.Background(), ChaosConfig{
+		CancelProbability: 0,
+		MaxDelay:          10 * time.Millisecond,
+	})
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("unexpected chaos cancellation")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	if !errors.Is(context.Cause(ctx), context.Canceled) {
+		t.Errorf("expected context.Canceled cause, got %v", context.Cause(ctx))
+	}
+}
+
+// TestChaosSeedSequence memastikan seed yang sama menghasilkan urutan
+// keputusan yang sama, dan urutan tersebut tetap bervariasi
+func TestChaosSeedSequence(t *testing.T) {
+	config := ChaosConfig{
+		CancelProbability: 0.5,
+		MinDelay:          10 * time.Millisecond,
+		MaxDelay:          100 * time.Millisecond,
+		Seed:              42,
+	}
+
+	first, second := NewChaos(config), NewChaos(config)
+
+	cancels, delays := 0, map[time.Duration]bool{}
+	for i := 0; i < 20; i++ {
+		cancel1, delay1 := first.decide()
+		cancel2, delay2 := second.decide()
+		if cancel1 != cancel2 || delay1 != delay2 {
+			t.Fatalf("decision %d differs for the same seed: (%v, %v) vs (%v, %v)", i, cancel1, delay1, cancel2, delay2)
+		}
+		if cancel1 {
+			cancels++
+			delays[delay1] = true
+		}
+	}
+
+	if cancels == 0 || cancels == 20 {
+		t.Errorf("expected a mix of decisions, got %d cancellations out of 20", cancels)
+	}
+	if len(delays) < 2 {
+		t.Errorf("expected varying delays, got %v", delays)
+	}
+}
+
+// TestChaosWithChaosSequence memastikan method WithChaos mengikuti urutan
+// keputusan dari sumber acak milik Chaos, bukan mengulang keputusan pertama
+func TestChaosWithChaosSequence(t *testing.T) {
+	config := ChaosConfig{CancelProbability: 0.5, MaxDelay: time.Millisecond, Seed: 7}
+	expected, chaos := NewChaos(config), NewChaos(config)
+
+	for i := 0; i < 20; i++ {
+		shouldCancel, _ := expected.decide()
+
+		ctx, cancel := chaos.WithChaos(context.Background())
+		select {
+		case <-ctx.Done():
+			if !shouldCancel {
+				t.Fatalf("decision %d: unexpected cancellation", i)
+			}
+		case <-time.After(50 * time.Millisecond):
+			if shouldCancel {
+				t.Fatalf("decision %d: expected cancellation", i)
+			}
+		}
+		cancel()
+	}
+}
@@ -3,9 +3,11 @@ package belajar_golang_context
 import (
 	"context"
 	"fmt"
-	"runtime"
 	"testing"
 	"time"
+
+	"belajar-golang-context/ratelimit"
+	"belajar-golang-context/taskgroup"
 )
 
 // TestContext adalah fungsi pengujian yang mendemonstrasikan dua jenis context dasar di Go:
@@ -70,16 +72,17 @@ func TestContextWithValue(t *testing.T) {
 }
 
 // CreateCounter membuat dan mengembalikan channel yang menghasilkan angka berurutan.
-// Parameter ctx digunakan untuk mengontrol lifecycle dari goroutine yang dijalankan.
+// Goroutine producer dijalankan di dalam group, dan context dari group digunakan
+// untuk mengontrol lifecycle-nya. group.Wait() menunggu sampai producer selesai.
 // Channel yang dikembalikan akan ditutup ketika context dibatalkan atau terjadi error.
-func CreateCounter(ctx context.Context) chan int {
+func CreateCounter(group *taskgroup.Group) chan int {
 	// Membuat channel unbuffered untuk mengirim nilai counter
 	// Best practice: Gunakan unbuffered channel untuk sinkronisasi yang lebih baik
 	destination := make(chan int)
 
 	// Menjalankan goroutine untuk menghasilkan nilai counter secara asynchronous
 	// Best practice: Selalu gunakan goroutine terpisah untuk operasi yang blocking
-	go func() {
+	group.Go(func(ctx context.Context) error {
 		// Memastikan channel selalu ditutup ketika fungsi selesai
 		// Best practice: Gunakan defer untuk mencegah resource leak
 		defer close(destination)
@@ -87,6 +90,10 @@ func CreateCounter(ctx context.Context) chan int {
 		// Inisialisasi counter dimulai dari 1
 		counter := 1
 
+		// Membatasi laju counter menjadi satu nilai per detik
+		// Best practice: Gunakan rate limiter yang menghormati context, bukan time.Sleep
+		limiter := ratelimit.NewLimiter(1*time.Second, 1)
+
 		// Loop tak terbatas untuk menghasilkan nilai counter
 		// Best practice: Gunakan select untuk handling pembatalan context
 		for {
@@ -94,20 +101,26 @@ func CreateCounter(ctx context.Context) chan int {
 			case <-ctx.Done():
 				// Menghentikan goroutine ketika context dibatalkan
 				// Best practice: Selalu handle pembatalan context
-				return
+				return nil
 			default:
+				// Menunggu giliran nilai berikutnya, atau berhenti
+				// lebih awal ketika context dibatalkan
+				if err := limiter.Wait(ctx); err != nil {
+					return nil
+				}
+
 				// Mengirim nilai counter ke channel
-				// Operasi ini akan blocking jika tidak ada yang menerima
-				destination <- counter
-				counter++
-
-				// Simulasi proses yang memakan waktu
-				// Note: Dalam kode produksi, hindari time.Sleep
-				// Best practice: Gunakan mekanisme rate limiting yang proper
-				time.Sleep(1 * time.Second)
+				// Pengiriman juga memantau ctx.Done() agar producer tidak tertahan
+				// ketika consumer sudah berhenti membaca, sehingga group.Wait() pasti selesai
+				select {
+				case <-ctx.Done():
+					return nil
+				case destination <- counter:
+					counter++
+				}
 			}
 		}
-	}()
+	})
 
 	// Mengembalikan channel yang akan digunakan oleh consumer
 	// Best practice: Channel producer hanya bertanggung jawab untuk menutup channel
@@ -117,9 +130,10 @@ func CreateCounter(ctx context.Context) chan int {
 // TestContextWithCancel adalah fungsi pengujian yang mendemonstrasikan penggunaan context.WithCancel
 // untuk mengelola dan membatalkan goroutine secara aman
 func TestContextWithCancel(t *testing.T) {
-	// Mencetak jumlah goroutine yang sedang berjalan sebelum memulai operasi
+	// Merekam jumlah goroutine selama skenario berjalan sebagai sparkline
 	// Berguna untuk memastikan tidak ada kebocoran goroutine
-	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+	chart := NewGoroutineChart(100 * time.Millisecond)
+	chart.Mark("awal")
 
 	// Membuat context induk yang kosong sebagai root context
 	parent := context.Background()
@@ -129,13 +143,16 @@ func TestContextWithCancel(t *testing.T) {
 	// cancel: fungsi yang akan digunakan untuk membatalkan operasi
 	ctx, cancel := context.WithCancel(parent)
 
+	// Membuat group yang menjalankan producer di bawah context yang dapat dibatalkan
+	group, ctx := taskgroup.WithContext(ctx)
+
 	// Membuat channel counter yang dapat dibatalkan menggunakan context
-	// CreateCounter akan menjalankan goroutine baru
-	destination := CreateCounter(ctx)
+	// CreateCounter akan menjalankan goroutine baru di dalam group
+	destination := CreateCounter(group)
 
-	// Mencetak jumlah goroutine setelah membuat counter
+	// Menandai jumlah goroutine setelah membuat counter
 	// Seharusnya bertambah 1 dari jumlah awal karena CreateCounter membuat goroutine baru
-	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+	chart.Mark("counter dibuat")
 
 	// Melakukan iterasi nilai dari channel destination
 	// Loop akan berhenti jika channel ditutup atau nilai mencapai 10
@@ -149,14 +166,17 @@ func TestContextWithCancel(t *testing.T) {
 	// Memanggil fungsi cancel untuk membatalkan context
 	// Ini akan mengirim sinyal pembatalan ke semua goroutine yang menggunakan context ini
 	cancel()
+	chart.Mark("cancel dipanggil")
 
-	// Memberikan waktu 2 detik untuk memastikan goroutine telah selesai dibersihkan
-	// Best practice: Dalam produksi, lebih baik menggunakan WaitGroup daripada sleep
-	time.Sleep(2 * time.Second)
+	// Menunggu sampai goroutine producer benar-benar selesai
+	// Best practice: Tunggu goroutine secara eksplisit, bukan dengan time.Sleep
+	group.Wait()
 
-	// Mencetak jumlah goroutine di akhir
+	// Menandai jumlah goroutine di akhir lalu mencetak chart
 	// Seharusnya kembali ke jumlah awal, menunjukkan tidak ada kebocoran goroutine
-	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+	chart.Mark("producer selesai")
+	chart.Stop()
+	fmt.Println(chart.Render())
 }
 
 // TestContextWithTimeout menguji penggunaan context dengan timeout.
@@ -165,9 +185,10 @@ func TestContextWithCancel(t *testing.T) {
 // - Menangani pembersihan resources dengan defer cancel
 // - Memantau jumlah goroutine untuk mencegah kebocoran
 func TestContextWithTimeout(t *testing.T) {
-	// Mencetak jumlah goroutine awal sebagai baseline
+	// Merekam jumlah goroutine sebagai sparkline, dimulai dari baseline
 	// Best practice: Selalu monitor jumlah goroutine sebelum operasi untuk deteksi kebocoran
-	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+	chart := NewGoroutineChart(100 * time.Millisecond)
+	chart.Mark("awal")
 
 	// Membuat context induk yang akan menjadi parent
 	// Best practice: Selalu gunakan Background() sebagai root context
@@ -182,37 +203,41 @@ func TestContextWithTimeout(t *testing.T) {
 	// Best practice: Selalu gunakan defer cancel() segera setelah WithTimeout/WithDeadline
 	defer cancel()
 
-	// Membuat counter yang akan dibatalkan oleh timeout
+	// Membuat counter di dalam group yang akan dibatalkan oleh timeout
 	// Best practice: Gunakan context untuk mengontrol lifecycle goroutine
-	destination := CreateCounter(ctx)
+	group, ctx := taskgroup.WithContext(ctx)
+	destination := CreateCounter(group)
 
-	// Mencetak jumlah goroutine setelah membuat counter
+	// Menandai jumlah goroutine setelah membuat counter
 	// Best practice: Monitor perubahan jumlah goroutine untuk memastikan creation berhasil
-	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+	chart.Mark("counter dibuat")
 
 	// Membaca nilai dari channel sampai channel ditutup (karena timeout atau selesai)
 	// Best practice: Gunakan range untuk membaca channel sampai ditutup
 	for n := range destination {
 		fmt.Println("Counter", n)
 	}
+	chart.Mark("channel ditutup")
 
-	// Memberikan waktu untuk cleanup
-	// Best practice: Dalam production, lebih baik menggunakan WaitGroup
-	// Note: time.Sleep sebaiknya dihindari dalam kode production
-	time.Sleep(2 * time.Second)
+	// Menunggu sampai goroutine producer benar-benar selesai
+	// Best practice: Gunakan group.Wait(), bukan time.Sleep, untuk menunggu cleanup
+	group.Wait()
 
-	// Memeriksa jumlah goroutine di akhir
+	// Menandai jumlah goroutine di akhir lalu mencetak chart
 	// Best practice: Pastikan jumlah goroutine kembali ke nilai awal
-	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+	chart.Mark("cleanup selesai")
+	chart.Stop()
+	fmt.Println(chart.Render())
 }
 
 // TestContextWithDeadline mendemonstrasikan penggunaan context.WithDeadline
 // untuk membatalkan operasi pada waktu tertentu di masa depan.
 // Best practice: Dokumentasikan tujuan utama fungsi di awal
 func TestContextWithDeadline(t *testing.T) {
-	// Mencetak jumlah goroutine awal sebagai baseline
+	// Merekam jumlah goroutine sebagai sparkline, dimulai dari baseline
 	// Best practice: Monitor jumlah goroutine untuk mendeteksi kebocoran
-	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+	chart := NewGoroutineChart(100 * time.Millisecond)
+	chart.Mark("awal")
 
 	// Membuat context induk sebagai root context
 	// Best practice: Selalu gunakan Background() sebagai parent context
@@ -227,27 +252,30 @@ func TestContextWithDeadline(t *testing.T) {
 	// Best practice: Selalu panggil cancel dengan defer segera setelah WithDeadline
 	defer cancel()
 
-	// Membuat counter yang akan dibatalkan ketika deadline tercapai
+	// Membuat counter di dalam group yang akan dibatalkan ketika deadline tercapai
 	// Best practice: Gunakan context untuk mengontrol lifecycle goroutine
-	destination := CreateCounter(ctx)
+	group, ctx := taskgroup.WithContext(ctx)
+	destination := CreateCounter(group)
 
-	// Memonitor perubahan jumlah goroutine setelah membuat counter
+	// Menandai perubahan jumlah goroutine setelah membuat counter
 	// Best practice: Pastikan goroutine creation berhasil dengan memeriksa jumlahnya
-	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+	chart.Mark("counter dibuat")
 
 	// Membaca nilai dari channel sampai channel ditutup (karena deadline atau pembatalan)
 	// Best practice: Gunakan range untuk membaca channel sampai selesai
 	for n := range destination {
 		fmt.Println("Counter", n)
 	}
+	chart.Mark("channel ditutup")
 
-	// Memberikan waktu untuk proses cleanup
-	// Best practice: Dalam production, lebih baik menggunakan WaitGroup
-	// Note: Hindari penggunaan time.Sleep dalam kode production
-	time.Sleep(2 * time.Second)
+	// Menunggu sampai goroutine producer benar-benar selesai
+	// Best practice: Gunakan group.Wait(), bukan time.Sleep, untuk menunggu cleanup
+	group.Wait()
 
-	// Memeriksa jumlah goroutine di akhir eksekusi
+	// Menandai jumlah goroutine di akhir eksekusi lalu mencetak chart
 	// Best practice: Pastikan tidak ada kebocoran goroutine dengan membandingkan
 	// jumlah akhir dengan jumlah awal
-	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+	chart.Mark("cleanup selesai")
+	chart.Stop()
+	fmt.Println(chart.Render())
 }
@@ -0,0 +1,36 @@
+// Package contextkey menyediakan key bertipe untuk context.WithValue,
+// sebagai pengganti key string yang rawan bentrok antar package.
+package contextkey
+
+import "context"
+
+// Key adalah key context yang membawa tipe nilainya sendiri.
+// Setiap Key dibandingkan berdasarkan identitas pointer, bukan nama,
+// sehingga dua key dengan nama yang sama tidak akan pernah bentrok.
+// Best practice: Simpan Key sebagai variabel package-level yang tidak diekspor
+type Key[T any] struct {
+	name string
+}
+
+// NewKey membuat key baru. Nama hanya digunakan untuk debugging
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// String mengembalikan nama key, berguna saat mencetak context
+func (key *Key[T]) String() string {
+	return "contextkey." + key.name
+}
+
+// WithValue membuat context turunan yang menyimpan val di bawah key.
+// Tipe val diperiksa saat kompilasi sesuai tipe key
+func WithValue[T any](ctx context.Context, key *Key[T], val T) context.Context {
+	return context.WithValue(ctx, key, val)
+}
+
+// FromContext mengambil nilai key dari context.
+// Mengembalikan false jika key tidak ada di rantai context
+func FromContext[T any](ctx context.Context, key *Key[T]) (T, bool) {
+	val, ok := ctx.Value(key).(T)
+	return val, ok
+}
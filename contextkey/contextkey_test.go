@@ -0,0 +1,53 @@
+package contextkey
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// TestWithValue mendemonstrasikan hierarki yang sama dengan TestContextWithValue,
+// tetapi menggunakan key bertipe
+func TestWithValue(t *testing.T) {
+	keyB := NewKey[string]("b")
+	keyC := NewKey[int]("c")
+
+	contextA := context.Background()
+	contextB := WithValue(contextA, keyB, "B")
+	contextC := WithValue(contextB, keyC, 3)
+	fmt.Println(contextC)
+
+	if b, ok := FromContext(contextC, keyB); !ok || b != "B" {
+		t.Errorf("expected B, got %q (ok=%v)", b, ok)
+	}
+	if c, ok := FromContext(contextC, keyC); !ok || c != 3 {
+		t.Errorf("expected 3, got %d (ok=%v)", c, ok)
+	}
+
+	// Context induk tidak dapat mengakses nilai dari context turunan
+	if _, ok := FromContext(contextA, keyB); ok {
+		t.Error("expected no value in parent context")
+	}
+}
+
+// TestKeyCollision memastikan dua key dengan nama yang sama tidak saling menimpa
+func TestKeyCollision(t *testing.T) {
+	first := NewKey[string]("user")
+	second := NewKey[string]("user")
+
+	ctx := WithValue(context.Background(), first, "alice")
+	ctx = WithValue(ctx, second, "bob")
+
+	if v, _ := FromContext(ctx, first); v != "alice" {
+		t.Errorf("expected alice, got %q", v)
+	}
+	if v, _ := FromContext(ctx, second); v != "bob" {
+		t.Errorf("expected bob, got %q", v)
+	}
+
+	// Key string biasa dengan nama yang sama juga tidak bentrok
+	ctx = context.WithValue(ctx, "user", "mallory")
+	if v, _ := FromContext(ctx, first); v != "alice" {
+		t.Errorf("expected alice after raw string key, got %q", v)
+	}
+}
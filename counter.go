@@ -0,0 +1,101 @@
+package belajar_golang_context
+
+import (
+	"context"
+	"time"
+
+	"belajar-golang-context/ratelimit"
+)
+
+// CounterOptions mengatur perilaku Counter
+type CounterOptions struct {
+	// Interval adalah jeda antar nilai. Nilai 0 berarti tanpa jeda
+	Interval time.Duration
+
+	// Start adalah nilai pertama yang dikirim
+	Start int
+
+	// Step adalah penambahan setiap nilai. Nilai 0 dianggap 1
+	Step int
+
+	// Buffer adalah kapasitas channel output. Nilai 0 berarti unbuffered
+	Buffer int
+
+	// Limit adalah jumlah nilai yang dikirim sebelum counter selesai.
+	// Nilai 0 berarti tanpa batas, counter hanya berhenti saat context selesai
+	Limit int
+}
+
+// Counter adalah versi CreateCounter yang dapat dikonfigurasi dan dipakai
+// di luar test. Selain channel nilai, Counter memberi tahu consumer kenapa
+// stream berhenti: pembatalan, deadline, atau selesai karena Limit tercapai.
+type Counter struct {
+	// C adalah channel nilai counter, ditutup ketika counter berhenti
+	C <-chan int
+
+	done chan struct{}
+	err  error
+}
+
+// NewCounter menjalankan goroutine producer yang mengirim nilai berurutan ke C
+// sampai ctx selesai atau Limit tercapai.
+// Best practice: Producer selalu select pada ctx.Done() saat mengirim,
+// sehingga tidak pernah tertahan ketika consumer sudah berhenti membaca
+func NewCounter(ctx context.Context, options CounterOptions) *Counter {
+	step := options.Step
+	if step == 0 {
+		step = 1
+	}
+
+	destination := make(chan int, options.Buffer)
+	counter := &Counter{
+		C:    destination,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		// Done ditutup sebelum C, sehingga consumer yang melihat C tertutup
+		// selalu bisa membaca Err() yang sudah final
+		defer close(destination)
+		defer close(counter.done)
+
+		// Best practice: Gunakan rate limiter yang menghormati context,
+		// bukan time.Sleep, agar jeda antar nilai bisa dihentikan kapan saja
+		limiter := ratelimit.NewLimiter(options.Interval, 1)
+
+		value := options.Start
+		for sent := 0; options.Limit == 0 || sent < options.Limit; sent++ {
+			if err := limiter.Wait(ctx); err != nil {
+				counter.err = err
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				counter.err = ctx.Err()
+				return
+			case destination <- value:
+				value += step
+			}
+		}
+	}()
+
+	return counter
+}
+
+// Done mengembalikan channel yang ditutup ketika producer sudah berhenti
+func (counter *Counter) Done() <-chan struct{} {
+	return counter.done
+}
+
+// Err mengembalikan alasan counter berhenti: context.Canceled,
+// context.DeadlineExceeded, atau nil jika counter selesai karena Limit
+// (maupun masih berjalan)
+func (counter *Counter) Err() error {
+	select {
+	case <-counter.done:
+		return counter.err
+	default:
+		return nil
+	}
+}
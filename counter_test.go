@@ -0,0 +1,66 @@
+package belajar_golang_context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"belajar-golang-context/internal/leaktest"
+)
+
+// TestCounterLimit memastikan counter selesai tanpa error ketika Limit tercapai
+func TestCounterLimit(t *testing.T) {
+	counter := NewCounter(context.Background(), CounterOptions{Start: 10, Step: 5, Limit: 4})
+
+	var values []int
+	for n := range counter.C {
+		values = append(values, n)
+	}
+
+	if fmt.Sprint(values) != "[10 15 20 25]" {
+		t.Errorf("unexpected values %v", values)
+	}
+	if err := counter.Err(); err != nil {
+		t.Errorf("expected nil error on completion, got %v", err)
+	}
+}
+
+// TestCounterCancel memastikan consumer bisa berhenti lebih awal tanpa
+// meninggalkan goroutine producer, dan Err() melaporkan context.Canceled
+func TestCounterCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	counter := NewCounter(ctx, CounterOptions{Start: 1, Interval: time.Millisecond})
+
+	for n := range counter.C {
+		if n == 10 {
+			break
+		}
+	}
+	cancel()
+	<-counter.Done()
+
+	if !errors.Is(counter.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", counter.Err())
+	}
+	leaktest.WaitGoroutines(t, before)
+}
+
+// TestCounterDeadline memastikan Err() melaporkan context.DeadlineExceeded
+// ketika counter berhenti karena timeout
+func TestCounterDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	counter := NewCounter(ctx, CounterOptions{Interval: 10 * time.Millisecond, Buffer: 2})
+	for range counter.C {
+	}
+
+	if !errors.Is(counter.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", counter.Err())
+	}
+}
@@ -0,0 +1,156 @@
+// Package ctxdebug membantu debugging rantai context yang bertingkat,
+// karena fmt.Println(ctx) hanya menampilkan sedikit informasi.
+package ctxdebug
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+var contextType = reflect.TypeFor[context.Context]()
+
+// Dump menelusuri context beserta semua parent-nya dan menampilkan satu baris
+// per context: tipe, pasangan key/value yang disimpan, deadline, dan status
+// pembatalan. Context dengan lebih dari satu parent (misalnya hasil
+// MergeContexts) ditampilkan sebagai cabang.
+// Note: Dump membaca field internal package context melalui reflection,
+// sehingga hanya ditujukan untuk debugging, bukan untuk logika program
+func Dump(ctx context.Context) string {
+	var out strings.Builder
+	dump(&out, ctx, "", "")
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+func dump(out *strings.Builder, ctx context.Context, prefix, childPrefix string) {
+	out.WriteString(prefix)
+	out.WriteString(describe(ctx))
+	out.WriteString("\n")
+
+	parents := parentsOf(ctx)
+	for i, parent := range parents {
+		if i == len(parents)-1 {
+			dump(out, parent, childPrefix+"└─ ", childPrefix+"   ")
+		} else {
+			dump(out, parent, childPrefix+"├─ ", childPrefix+"│  ")
+		}
+	}
+}
+
+// describe membuat ringkasan satu context tanpa parent-nya
+func describe(ctx context.Context) string {
+	v := reflect.ValueOf(ctx)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	parts := []string{v.Type().String()}
+
+	if v.Kind() == reflect.Struct {
+		if key, ok := field(v, "key"); ok {
+			parts = append(parts, "key="+format(key))
+		}
+		if val, ok := field(v, "val"); ok {
+			parts = append(parts, "val="+format(val))
+		}
+		if deadline, ok := field(v, "deadline"); ok {
+			if d, ok := deadline.(time.Time); ok {
+				parts = append(parts, fmt.Sprintf("deadline=%s (sisa %s)", d.Format(time.RFC3339Nano), time.Until(d).Round(time.Millisecond)))
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		state := "err=" + err.Error()
+		if cause := context.Cause(ctx); cause != nil && cause != err {
+			state += " cause=" + cause.Error()
+		}
+		parts = append(parts, state)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// field membaca field (termasuk yang tidak diekspor) dari struct context.
+// Jika v tidak addressable, nilainya disalin terlebih dahulu
+func field(v reflect.Value, name string) (any, bool) {
+	f := v.FieldByName(name)
+	if !f.IsValid() {
+		return nil, false
+	}
+	if !f.CanAddr() {
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		f = copied.FieldByName(name)
+	}
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem().Interface(), true
+}
+
+// parentsOf mencari parent dari sebuah context: field bertipe context.Context
+// (termasuk yang di-embed di struct lain) atau []context.Context
+func parentsOf(ctx context.Context) []context.Context {
+	v := reflect.ValueOf(ctx)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	if !v.CanAddr() {
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		v = copied
+	}
+	return findParents(v)
+}
+
+func findParents(v reflect.Value) []context.Context {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		f = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+
+		switch {
+		case f.Type() == contextType:
+			if parent, ok := f.Interface().(context.Context); ok && parent != nil {
+				return []context.Context{parent}
+			}
+		case f.Kind() == reflect.Slice && f.Type().Elem() == contextType:
+			return f.Interface().([]context.Context)
+		case f.Kind() == reflect.Struct && v.Type().Field(i).Anonymous:
+			if parents := findParents(f); parents != nil {
+				return parents
+			}
+		}
+	}
+	return nil
+}
+
+func format(value any) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(value)
+}
+
+// Watch mencatat ke log standar kapan dan kenapa ctx selesai,
+// termasuk berapa lama sejak Watch dipanggil.
+// Fungsi stop yang dikembalikan membatalkan pengamatan,
+// mengikuti perilaku context.AfterFunc
+func Watch(ctx context.Context, name string) (stop func() bool) {
+	start := time.Now()
+	return context.AfterFunc(ctx, func() {
+		elapsed := time.Since(start).Round(time.Millisecond)
+		if cause := context.Cause(ctx); cause != nil && cause != ctx.Err() {
+			log.Printf("ctxdebug: %s selesai setelah %s: %v (cause: %v)", name, elapsed, ctx.Err(), cause)
+			return
+		}
+		log.Printf("ctxdebug: %s selesai setelah %s: %v", name, elapsed, ctx.Err())
+	})
+}
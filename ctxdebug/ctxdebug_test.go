@@ -0,0 +1,97 @@
+package ctxdebug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	belajar_golang_context "belajar-golang-context"
+)
+
+// TestDump memakai rantai yang sama dengan TestContextWithValue
+// (contextA → contextB → contextD) ditambah pembatalan dan deadline
+func TestDump(t *testing.T) {
+	contextA := context.Background()
+	contextB := context.WithValue(contextA, "b", "B")
+	contextC, cancel := context.WithCancel(contextB)
+	cancel()
+	contextD, cancelD := context.WithTimeout(context.WithValue(contextC, "d", "D"), time.Minute)
+	defer cancelD()
+
+	dump := Dump(contextD)
+	fmt.Println(dump)
+
+	lines := strings.Split(dump, "\n")
+	expected := []string{
+		"context.timerCtx deadline=",
+		"└─ context.valueCtx key=\"d\" val=\"D\" err=context canceled",
+		"   └─ context.cancelCtx err=context canceled",
+		"      └─ context.valueCtx key=\"b\" val=\"B\"",
+		"         └─ context.backgroundCtx",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(expected), len(lines), dump)
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("line %d: expected prefix %q, got %q", i, prefix, lines[i])
+		}
+	}
+}
+
+// TestDumpMerged memastikan context dengan beberapa parent ditampilkan sebagai cabang
+func TestDumpMerged(t *testing.T) {
+	request := context.WithValue(context.Background(), "request", "R")
+	shutdown := context.WithValue(context.TODO(), "shutdown", "S")
+
+	ctx, cancel := belajar_golang_context.MergeContexts(request, shutdown)
+	defer cancel()
+
+	dump := Dump(ctx)
+	fmt.Println(dump)
+
+	for _, want := range []string{
+		"├─ context.valueCtx key=\"request\" val=\"R\"",
+		"│  └─ context.backgroundCtx",
+		"└─ context.valueCtx key=\"shutdown\" val=\"S\"",
+		"   └─ context.todoCtx",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("expected dump to contain %q", want)
+		}
+	}
+}
+
+// TestWatch memastikan Watch mencatat alasan pembatalan
+func TestWatch(t *testing.T) {
+	logs := make(logWriter, 1)
+	log.SetOutput(logs)
+	defer log.SetOutput(os.Stderr)
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	Watch(ctx, "worker")
+	cancel(errors.New("shutdown"))
+
+	select {
+	case line := <-logs:
+		if !strings.Contains(line, "ctxdebug: worker selesai setelah") || !strings.Contains(line, "(cause: shutdown)") {
+			t.Errorf("unexpected log %q", line)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Watch to log the cancellation")
+	}
+}
+
+// logWriter meneruskan setiap baris log ke channel,
+// karena Watch menulis log dari goroutine lain
+type logWriter chan string
+
+func (w logWriter) Write(p []byte) (int, error) {
+	w <- string(p)
+	return len(p), nil
+}
@@ -0,0 +1,25 @@
+package belajar_golang_context
+
+import (
+	"context"
+	"time"
+)
+
+// Detach membuat context yang mewarisi semua nilai dari parent,
+// tetapi tidak ikut dibatalkan ketika parent dibatalkan.
+// Done() mengembalikan nil, Err() selalu nil, dan Deadline() tidak ada.
+// Cocok untuk pekerjaan fire-and-forget seperti audit log atau flush metrics
+// yang dimulai dari handler request.
+// Note: Dibangun di atas context.WithoutCancel agar context.Cause dan context
+// turunan berperilaku sama seperti context standar
+func Detach(parent context.Context) context.Context {
+	return context.WithoutCancel(parent)
+}
+
+// DetachWithTimeout sama seperti Detach, tetapi memberi batas waktu sendiri
+// yang tidak bergantung pada lifecycle parent.
+// Best practice: Pekerjaan yang dilepas dari request tetap perlu batas waktu
+// agar goroutine tidak berjalan selamanya
+func DetachWithTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(Detach(parent), timeout)
+}
@@ -0,0 +1,53 @@
+package belajar_golang_context
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestDetach memastikan nilai tetap tersedia setelah parent dibatalkan,
+// sementara Done() dan Deadline() tidak diwarisi
+func TestDetach(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.WithValue(context.Background(), "request-id", "abc"), time.Second)
+	detached := Detach(parent)
+	cancel()
+
+	if detached.Value("request-id") != "abc" {
+		t.Errorf("expected request-id to survive, got %v", detached.Value("request-id"))
+	}
+	if detached.Done() != nil {
+		t.Error("expected nil Done channel")
+	}
+	if detached.Err() != nil {
+		t.Errorf("expected nil Err, got %v", detached.Err())
+	}
+	if _, ok := detached.Deadline(); ok {
+		t.Error("expected no deadline")
+	}
+	if context.Cause(detached) != nil {
+		t.Errorf("expected nil cause, got %v", context.Cause(detached))
+	}
+}
+
+// TestDetachWithTimeout memastikan context yang dilepas memakai timeout-nya sendiri
+func TestDetachWithTimeout(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.WithValue(context.Background(), "request-id", "abc"))
+
+	ctx, cancel := DetachWithTimeout(parent, 50*time.Millisecond)
+	defer cancel()
+
+	cancelParent()
+	if ctx.Err() != nil {
+		t.Fatalf("parent cancellation must not propagate, got %v", ctx.Err())
+	}
+	if ctx.Value("request-id") != "abc" {
+		t.Errorf("expected request-id to survive, got %v", ctx.Value("request-id"))
+	}
+
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", ctx.Err())
+	}
+}
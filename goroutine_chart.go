@@ -0,0 +1,182 @@
+package belajar_golang_context
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sparkLevels adalah karakter yang digunakan untuk menggambar sparkline,
+// diurutkan dari nilai terendah ke tertinggi
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// chartWidth adalah lebar maksimum sparkline dalam karakter.
+// Jika jumlah sampel lebih banyak, beberapa sampel digabung menjadi satu kolom
+const chartWidth = 80
+
+type goroutineSample struct {
+	at    time.Duration
+	count int
+}
+
+type chartEvent struct {
+	sample int
+	label  string
+}
+
+// GoroutineChart merekam jumlah goroutine (runtime.NumGoroutine) secara berkala
+// selama sebuah skenario berjalan, beserta event lifecycle seperti
+// "counter dibuat" atau "cancel dipanggil", lalu menggambarnya sebagai sparkline.
+// Best practice: Panggil Stop sebelum Render agar goroutine sampler ikut dibersihkan
+type GoroutineChart struct {
+	interval time.Duration
+	start    time.Time
+
+	mu      sync.Mutex
+	samples []goroutineSample
+	events  []chartEvent
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewGoroutineChart membuat chart dan langsung mulai mengambil sampel
+// jumlah goroutine setiap interval.
+// Note: Sampler berjalan di goroutine sendiri, sehingga jumlah yang tercatat
+// sudah termasuk goroutine sampler tersebut
+func NewGoroutineChart(interval time.Duration) *GoroutineChart {
+	chart := &GoroutineChart{
+		interval: interval,
+		start:    time.Now(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		defer close(chart.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-chart.stop:
+				return
+			case <-ticker.C:
+				chart.sample()
+			}
+		}
+	}()
+
+	return chart
+}
+
+// sample mencatat jumlah goroutine saat ini dan mengembalikan indeks sampelnya
+func (chart *GoroutineChart) sample() int {
+	count := runtime.NumGoroutine()
+
+	chart.mu.Lock()
+	defer chart.mu.Unlock()
+	chart.samples = append(chart.samples, goroutineSample{at: time.Since(chart.start), count: count})
+	return len(chart.samples) - 1
+}
+
+// Mark mencatat event lifecycle pada titik waktu saat ini.
+// Sebuah sampel diambil tepat saat Mark dipanggil agar posisi event
+// di chart sesuai dengan jumlah goroutine pada saat itu
+func (chart *GoroutineChart) Mark(label string) {
+	index := chart.sample()
+
+	chart.mu.Lock()
+	defer chart.mu.Unlock()
+	chart.events = append(chart.events, chartEvent{sample: index, label: label})
+}
+
+// Stop menghentikan sampler dan menunggu goroutine-nya selesai.
+// Aman dipanggil lebih dari sekali
+func (chart *GoroutineChart) Stop() {
+	chart.stopOnce.Do(func() {
+		close(chart.stop)
+	})
+	<-chart.done
+}
+
+// Render menggambar sparkline jumlah goroutine, baris penanda event
+// yang sejajar dengan kolom sparkline, dan legenda setiap event
+func (chart *GoroutineChart) Render() string {
+	chart.mu.Lock()
+	defer chart.mu.Unlock()
+
+	if len(chart.samples) == 0 {
+		return ""
+	}
+
+	// Menggabungkan sampel ke dalam kolom, mengambil nilai maksimum per kolom
+	// agar lonjakan singkat tetap terlihat. Kolom yang berisi event memakai
+	// jumlah goroutine pada event pertamanya, supaya nilai penting seperti
+	// baseline "awal" tidak tertutup oleh sampel lain di kolom yang sama
+	width := min(len(chart.samples), chartWidth)
+	columns := make([]int, width)
+	column := func(index int) int {
+		return index * width / len(chart.samples)
+	}
+	for i, s := range chart.samples {
+		col := column(i)
+		columns[col] = max(columns[col], s.count)
+	}
+	pinned := make([]bool, width)
+	for _, event := range chart.events {
+		col := column(event.sample)
+		if !pinned[col] {
+			columns[col] = chart.samples[event.sample].count
+			pinned[col] = true
+		}
+	}
+
+	low, high := chart.samples[0].count, chart.samples[0].count
+	for _, s := range chart.samples {
+		low = min(low, s.count)
+		high = max(high, s.count)
+	}
+
+	var spark strings.Builder
+	for _, count := range columns {
+		level := 0
+		if high > low {
+			level = (count - low) * (len(sparkLevels) - 1) / (high - low)
+		}
+		spark.WriteRune(sparkLevels[level])
+	}
+
+	// Event yang jatuh di kolom yang sudah terisi digeser ke kolom kosong
+	// berikutnya, sehingga tidak ada penanda yang saling menimpa
+	markers := []rune(strings.Repeat(" ", width))
+	for i, event := range chart.events {
+		marker := '*'
+		if i < 9 {
+			marker = rune('1' + i)
+		}
+		col := column(event.sample)
+		for col < len(markers) && markers[col] != ' ' {
+			col++
+		}
+		if col == len(markers) {
+			markers = append(markers, ' ')
+		}
+		markers[col] = marker
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "Goroutine (min %d, max %d, %d sampel tiap %s)\n", low, high, len(chart.samples), chart.interval)
+	out.WriteString(spark.String())
+	out.WriteString("\n")
+	out.WriteString(strings.TrimRight(string(markers), " "))
+	for i, event := range chart.events {
+		s := chart.samples[event.sample]
+		fmt.Fprintf(&out, "\n%d. %s (t=%s, goroutine=%d)", i+1, event.label, s.at.Round(time.Millisecond), s.count)
+	}
+	return out.String()
+}
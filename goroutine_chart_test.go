@@ -0,0 +1,88 @@
+package belajar_golang_context
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestGoroutineChart memastikan chart mencatat event lifecycle
+// dan menggambar penanda yang sejajar dengan sparkline
+func TestGoroutineChart(t *testing.T) {
+	chart := NewGoroutineChart(10 * time.Millisecond)
+	chart.Mark("awal")
+
+	stop := make(chan struct{})
+	go func() {
+		<-stop
+	}()
+	time.Sleep(50 * time.Millisecond)
+	chart.Mark("goroutine dibuat")
+
+	close(stop)
+	time.Sleep(50 * time.Millisecond)
+	chart.Mark("goroutine selesai")
+
+	chart.Stop()
+	chart.Stop() // Stop aman dipanggil lebih dari sekali
+
+	rendered := chart.Render()
+	fmt.Println(rendered)
+
+	lines := strings.Split(rendered, "\n")
+	if len(lines) != 6 {
+		t.Fatalf("expected 6 lines, got %d:\n%s", len(lines), rendered)
+	}
+	if !strings.HasPrefix(lines[0], "Goroutine (min ") {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[2], "1") || !strings.Contains(lines[2], "2") || !strings.HasSuffix(lines[2], "3") {
+		t.Errorf("unexpected marker line %q", lines[2])
+	}
+	if !strings.HasPrefix(lines[4], "2. goroutine dibuat") {
+		t.Errorf("unexpected legend %q", lines[4])
+	}
+}
+
+// TestGoroutineChartCollidingMarks memastikan event yang berdekatan tetap
+// tampil semua ketika sampel lebih banyak dari lebar chart
+func TestGoroutineChartCollidingMarks(t *testing.T) {
+	chart := NewGoroutineChart(time.Millisecond)
+	chart.Mark("awal")
+
+	stop := make(chan struct{})
+	go func() {
+		<-stop
+	}()
+	chart.Mark("goroutine dibuat")
+
+	for {
+		chart.mu.Lock()
+		n := len(chart.samples)
+		chart.mu.Unlock()
+		if n > 2*chartWidth {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	close(stop)
+	chart.Stop()
+
+	rendered := chart.Render()
+	fmt.Println(rendered)
+
+	lines := strings.Split(rendered, "\n")
+	if !strings.HasPrefix(lines[2], "12") {
+		t.Errorf("expected both markers at the start, got %q", lines[2])
+	}
+
+	// Kolom pertama menunjukkan baseline, yaitu level terendah
+	baseline := chart.samples[chart.events[0].sample].count
+	if !strings.Contains(lines[0], fmt.Sprintf("min %d,", baseline)) {
+		t.Errorf("expected baseline %d as minimum, got %q", baseline, lines[0])
+	}
+	if []rune(lines[1])[0] != sparkLevels[0] {
+		t.Errorf("expected first column at the lowest level, got %q", lines[1])
+	}
+}
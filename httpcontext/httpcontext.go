@@ -0,0 +1,150 @@
+// Package httpcontext menyediakan middleware dan RoundTripper yang membawa
+// nilai request-scoped (request ID dan deadline) melalui context,
+// baik untuk request masuk maupun request keluar.
+package httpcontext
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"belajar-golang-context/contextkey"
+)
+
+const (
+	// HeaderRequestID membawa request ID antar service
+	HeaderRequestID = "X-Request-ID"
+
+	// HeaderTimeout membawa sisa waktu request dalam milidetik.
+	// Sisa waktu (bukan waktu absolut) dipakai agar tidak terpengaruh
+	// perbedaan jam antar mesin
+	HeaderTimeout = "X-Request-Timeout"
+)
+
+// maxRequestIDLength adalah panjang maksimum request ID dari caller yang diterima
+const maxRequestIDLength = 128
+
+// maxRequestTimeout adalah batas atas X-Request-Timeout dari caller.
+// Nilai yang lebih besar dipotong agar perkalian ke time.Duration
+// tidak overflow menjadi timeout negatif
+const maxRequestTimeout = 24 * time.Hour
+
+var requestIDKey = contextkey.NewKey[string]("request-id")
+
+// WithRequestID membuat context turunan yang membawa request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return contextkey.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID mengambil request ID dari context
+func RequestID(ctx context.Context) (string, bool) {
+	return contextkey.FromContext(ctx, requestIDKey)
+}
+
+// newRequestID membuat request ID acak 128-bit dalam bentuk hex
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// validRequestID memeriksa request ID dari caller: tidak kosong, tidak lebih
+// dari maxRequestIDLength, dan hanya berisi huruf, angka, '-', '_', '.', atau ':'.
+// Request ID ikut dicetak di log dan dikirim balik di header,
+// sehingga nilai sembarang dari client tidak boleh diteruskan apa adanya
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range []byte(id) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Middleware memasang request ID ke context request, memakai header
+// X-Request-ID dari caller jika valid atau membuat yang baru, lalu
+// mengirimkannya kembali di response. Jika caller mengirim X-Request-Timeout,
+// context request diberi timeout sesuai sisa waktu caller.
+// Best practice: Pasang middleware ini paling luar agar semua handler melihat nilainya
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		id := r.Header.Get(HeaderRequestID)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		ctx = WithRequestID(ctx, id)
+		w.Header().Set(HeaderRequestID, id)
+
+		if ms, err := strconv.ParseInt(r.Header.Get(HeaderTimeout), 10, 64); err == nil && ms > 0 {
+			var cancel context.CancelFunc
+			ms = min(ms, maxRequestTimeout.Milliseconds())
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(ms)*time.Millisecond)
+			defer cancel()
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Timeout membatasi waktu eksekusi handler untuk satu route dengan menerapkan
+// context.WithTimeout pada r.Context(). Jika context request sudah memiliki
+// deadline yang lebih awal, deadline tersebut tetap berlaku
+func Timeout(timeout time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Transport adalah http.RoundTripper yang meneruskan request ID dan
+// sisa deadline dari context request keluar ke dalam header
+type Transport struct {
+	// Base adalah RoundTripper yang dibungkus. Nilai nil berarti http.DefaultTransport
+	Base http.RoundTripper
+}
+
+// RoundTrip mengisi header X-Request-ID dan X-Request-Timeout dari context request.
+// Header yang sudah diisi caller tidak ditimpa
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// Best practice: RoundTripper tidak boleh mengubah request milik caller
+	req = req.Clone(ctx)
+
+	if id, ok := RequestID(ctx); ok && req.Header.Get(HeaderRequestID) == "" {
+		req.Header.Set(HeaderRequestID, id)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && req.Header.Get(HeaderTimeout) == "" {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			// Kontrak http.RoundTripper: body selalu ditutup, termasuk saat error
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, context.DeadlineExceeded
+		}
+		// Dibulatkan ke atas agar sisa waktu di bawah 1ms tidak terkirim sebagai 0
+		ms := (remaining + time.Millisecond - 1) / time.Millisecond
+		req.Header.Set(HeaderTimeout, strconv.FormatInt(int64(ms), 10))
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
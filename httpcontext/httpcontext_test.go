@@ -0,0 +1,171 @@
+package httpcontext
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEndToEnd memastikan request ID dan deadline dari client
+// sampai ke handler di server melalui Transport dan Middleware
+func TestEndToEnd(t *testing.T) {
+	var gotID string
+	var gotDeadline time.Time
+	var hasDeadline bool
+
+	server := httptest.NewServer(Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID, _ = RequestID(r.Context())
+		gotDeadline, hasDeadline = r.Context().Deadline()
+	})))
+	defer server.Close()
+
+	client := &http.Client{Transport: &Transport{}}
+
+	ctx, cancel := context.WithTimeout(WithRequestID(context.Background(), "req-123"), 2*time.Second)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if gotID != "req-123" {
+		t.Errorf("expected request ID req-123, got %q", gotID)
+	}
+	if resp.Header.Get(HeaderRequestID) != "req-123" {
+		t.Errorf("expected request ID echoed in response, got %q", resp.Header.Get(HeaderRequestID))
+	}
+	if !hasDeadline {
+		t.Fatal("expected server context to have a deadline")
+	}
+	if diff := gotDeadline.Sub(deadline); diff > 100*time.Millisecond || diff < -100*time.Millisecond {
+		t.Errorf("expected server deadline near %v, got %v", deadline, gotDeadline)
+	}
+	if req.Header.Get(HeaderRequestID) != "" {
+		t.Error("transport must not modify the caller's request")
+	}
+}
+
+// TestMiddlewareGeneratesRequestID memastikan request tanpa header
+// tetap mendapat request ID baru
+func TestMiddlewareGeneratesRequestID(t *testing.T) {
+	var gotID string
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID, _ = RequestID(r.Context())
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if len(gotID) != 32 {
+		t.Errorf("expected 32-character request ID, got %q", gotID)
+	}
+	if recorder.Header().Get(HeaderRequestID) != gotID {
+		t.Errorf("expected response header %q, got %q", gotID, recorder.Header().Get(HeaderRequestID))
+	}
+}
+
+// TestTimeout memastikan timeout per-route membatalkan context handler
+func TestTimeout(t *testing.T) {
+	var gotErr error
+	handler := Timeout(50*time.Millisecond, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			gotErr = r.Context().Err()
+		case <-time.After(time.Second):
+		}
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	if !errors.Is(gotErr, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", gotErr)
+	}
+}
+
+// TestMiddlewareRejectsInvalidRequestID memastikan request ID dari caller
+// yang terlalu panjang atau berisi karakter tidak valid diganti dengan yang baru
+func TestMiddlewareRejectsInvalidRequestID(t *testing.T) {
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, id := range []string{
+		strings.Repeat("a", maxRequestIDLength+1),
+		"abc\r\nX-Injected: 1",
+		"<script>",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(HeaderRequestID, id)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		if got := recorder.Header().Get(HeaderRequestID); got == id || len(got) != 32 {
+			t.Errorf("expected regenerated request ID for %q, got %q", id, got)
+		}
+	}
+}
+
+// closeTracker mencatat apakah body request sudah ditutup
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (body *closeTracker) Close() error {
+	body.closed = true
+	return nil
+}
+
+// TestTransportExpiredClosesBody memastikan body ditutup ketika
+// request ditolak karena deadline sudah lewat
+func TestTransportExpiredClosesBody(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	body := &closeTracker{Reader: strings.NewReader("data")}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.invalid", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = (&Transport{}).RoundTrip(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if !body.closed {
+		t.Error("expected request body to be closed")
+	}
+}
+
+// TestMiddlewareClampsTimeout memastikan X-Request-Timeout yang sangat besar
+// dipotong ke maxRequestTimeout, bukan overflow menjadi deadline yang sudah lewat
+func TestMiddlewareClampsTimeout(t *testing.T) {
+	var deadline time.Time
+	var ctxErr error
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, _ = r.Context().Deadline()
+		ctxErr = r.Context().Err()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderTimeout, "9300000000000")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if ctxErr != nil {
+		t.Fatalf("expected live context, got %v", ctxErr)
+	}
+	if remaining := time.Until(deadline); remaining <= maxRequestTimeout-time.Minute || remaining > maxRequestTimeout {
+		t.Errorf("expected deadline clamped to %v, got %v remaining", maxRequestTimeout, remaining)
+	}
+}
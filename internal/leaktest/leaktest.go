@@ -0,0 +1,28 @@
+// Package leaktest berisi helper test untuk mendeteksi kebocoran goroutine
+// menggunakan runtime.NumGoroutine(), seperti contoh-contoh di package utama.
+package leaktest
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// WaitGoroutines menunggu sampai jumlah goroutine kembali ke baseline before,
+// lalu menggagalkan test jika masih ada goroutine yang tertinggal.
+// Polling diperlukan karena goroutine baru benar-benar keluar sedikit
+// setelah memberi sinyal selesai (misalnya lewat wg.Done atau close channel)
+func WaitGoroutines(t testing.TB, before int) {
+	t.Helper()
+
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	after := runtime.NumGoroutine()
+	fmt.Println("Total Goroutine", after)
+	if after > before {
+		t.Errorf("goroutine leak: before %d, after %d", before, after)
+	}
+}
@@ -0,0 +1,106 @@
+package belajar_golang_context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// mergedContext adalah context yang selesai ketika salah satu parent-nya selesai.
+// Context yang di-embed adalah context standar turunan parent pertama dengan
+// deadline paling awal dari semua parent, sehingga Err(), context.Cause,
+// dan context turunan bekerja seperti context biasa
+type mergedContext struct {
+	parents []context.Context
+	context.Context
+}
+
+// MergeContexts membuat context yang dibatalkan ketika parent mana pun selesai,
+// misalnya context request dan context shutdown server.
+// Err() dan context.Cause mengikuti parent yang selesai paling awal, Deadline()
+// adalah deadline paling awal, dan Value() mencari key di setiap parent sesuai
+// urutan argumen.
+// Best practice: Selalu panggil cancel dengan defer agar pendaftaran pada parent dilepas
+func MergeContexts(parents ...context.Context) (context.Context, context.CancelFunc) {
+	if len(parents) == 0 {
+		parents = []context.Context{context.Background()}
+	}
+
+	// Deadline paling awal dipasang lewat WithDeadline, sehingga kedaluwarsa
+	// parent mana pun diproses oleh timer context standar dan context turunan
+	// ikut mendapat context.DeadlineExceeded
+	base, stopDeadline := parents[0], context.CancelFunc(func() {})
+	var deadline time.Time
+	var hasDeadline bool
+	for _, parent := range parents {
+		if d, ok := parent.Deadline(); ok && (!hasDeadline || d.Before(deadline)) {
+			deadline, hasDeadline = d, true
+		}
+	}
+	if hasDeadline {
+		base, stopDeadline = context.WithDeadline(parents[0], deadline)
+	}
+
+	ctx, cancel := context.WithCancelCause(base)
+	merged := &mergedContext{parents: parents, Context: ctx}
+
+	// Parent pertama sudah terhubung lewat WithCancelCause. Parent lain
+	// dipantau dengan context.AfterFunc, yang tidak membuat goroutine
+	// sampai parent tersebut selesai
+	stops := []func() bool{}
+	for _, parent := range parents[1:] {
+		if parent.Err() != nil {
+			cancelFrom(cancel, parent)
+			break
+		}
+		stops = append(stops, context.AfterFunc(parent, func() {
+			cancelFrom(cancel, parent)
+		}))
+	}
+
+	// Melepas pendaftaran pada parent lain dan timer deadline
+	// begitu context ini selesai, apa pun penyebabnya
+	context.AfterFunc(ctx, func() {
+		for _, stop := range stops {
+			stop()
+		}
+		stopDeadline()
+	})
+
+	return merged, func() { cancel(context.Canceled) }
+}
+
+// cancelFrom meneruskan pembatalan parent ke context hasil merge beserta cause-nya.
+// Parent yang kedaluwarsa dilewati karena timer deadline context hasil merge,
+// yang tidak lebih lambat dari deadline parent tersebut, sudah menanganinya
+// dengan Err() context.DeadlineExceeded
+func cancelFrom(cancel context.CancelCauseFunc, parent context.Context) {
+	if _, ok := parent.Deadline(); ok && errors.Is(parent.Err(), context.DeadlineExceeded) {
+		return
+	}
+	cancel(context.Cause(parent))
+}
+
+func (merged *mergedContext) Value(key any) any {
+	// Context yang di-embed sudah mencakup parent pertama
+	// serta key internal package context yang dipakai oleh context.Cause
+	if value := merged.Context.Value(key); value != nil {
+		return value
+	}
+	for _, parent := range merged.parents[1:] {
+		if value := parent.Value(key); value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+func (merged *mergedContext) String() string {
+	names := make([]string, len(merged.parents))
+	for i, parent := range merged.parents {
+		names[i] = fmt.Sprint(parent)
+	}
+	return "MergeContexts(" + strings.Join(names, ", ") + ")"
+}
@@ -0,0 +1,174 @@
+package belajar_golang_context
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"belajar-golang-context/internal/leaktest"
+)
+
+// TestMergeContexts mendemonstrasikan context request dan context shutdown
+// yang digabung: context hasil merge selesai ketika salah satunya selesai
+func TestMergeContexts(t *testing.T) {
+	request, cancelRequest := context.WithTimeout(context.WithValue(context.Background(), "request", "R"), 50*time.Millisecond)
+	defer cancelRequest()
+
+	shutdown, cancelShutdown := context.WithCancel(context.WithValue(context.Background(), "shutdown", "S"))
+	defer cancelShutdown()
+
+	ctx, cancel := MergeContexts(request, shutdown)
+	defer cancel()
+	fmt.Println(ctx)
+
+	// Value dicari di semua parent
+	if ctx.Value("request") != "R" || ctx.Value("shutdown") != "S" {
+		t.Errorf("expected values from both parents, got %v and %v", ctx.Value("request"), ctx.Value("shutdown"))
+	}
+
+	// Deadline mengikuti parent dengan deadline paling awal
+	requestDeadline, _ := request.Deadline()
+	if deadline, ok := ctx.Deadline(); !ok || !deadline.Equal(requestDeadline) {
+		t.Errorf("expected deadline %v, got %v (ok=%v)", requestDeadline, deadline, ok)
+	}
+
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", ctx.Err())
+	}
+}
+
+// TestMergeContextsSecondParent memastikan pembatalan parent kedua ikut diteruskan
+func TestMergeContextsSecondParent(t *testing.T) {
+	first, cancelFirst := context.WithCancel(context.Background())
+	defer cancelFirst()
+	second, cancelSecond := context.WithCancel(context.Background())
+
+	ctx, cancel := MergeContexts(first, second)
+	defer cancel()
+
+	cancelSecond()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected merged context to be done")
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", ctx.Err())
+	}
+	if first.Err() != nil {
+		t.Error("merged cancellation must not cancel its parents")
+	}
+}
+
+// TestMergeContextsCause memastikan context.Cause dan context turunan
+// mengikuti parent yang benar-benar memicu pembatalan
+func TestMergeContextsCause(t *testing.T) {
+	first, cancelFirst := context.WithCancelCause(context.Background())
+	defer cancelFirst(nil)
+	second, cancelSecond := context.WithCancelCause(context.Background())
+
+	ctx, cancel := MergeContexts(first, second)
+	defer cancel()
+
+	child, cancelChild := context.WithCancel(ctx)
+	defer cancelChild()
+
+	shutdown := errors.New("shutdown")
+	cancelSecond(shutdown)
+
+	select {
+	case <-child.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected child of merged context to be done")
+	}
+	if !errors.Is(context.Cause(ctx), shutdown) {
+		t.Errorf("expected cause %v, got %v", shutdown, context.Cause(ctx))
+	}
+	if !errors.Is(context.Cause(child), shutdown) {
+		t.Errorf("expected child cause %v, got %v", shutdown, context.Cause(child))
+	}
+}
+
+// multiErr adalah error bertipe slice yang tidak bisa dibandingkan dengan ==
+type multiErr []error
+
+func (errs multiErr) Error() string {
+	return fmt.Sprint([]error(errs))
+}
+
+// TestMergeContextsUncomparableCause memastikan cause dengan tipe yang tidak
+// comparable diteruskan tanpa panic
+func TestMergeContextsUncomparableCause(t *testing.T) {
+	first, cancelFirst := context.WithCancel(context.Background())
+	defer cancelFirst()
+	second, cancelSecond := context.WithCancelCause(context.Background())
+
+	ctx, cancel := MergeContexts(first, second)
+	defer cancel()
+
+	cause := multiErr{errors.New("db"), errors.New("cache")}
+	cancelSecond(cause)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected merged context to be done")
+	}
+	if got, ok := context.Cause(ctx).(multiErr); !ok || len(got) != 2 {
+		t.Errorf("expected multiErr cause, got %v", context.Cause(ctx))
+	}
+}
+
+// TestMergeContextsChildDeadline memastikan context turunan ikut mendapat
+// context.DeadlineExceeded ketika deadline parent selain parent pertama tercapai
+func TestMergeContextsChildDeadline(t *testing.T) {
+	request, cancelRequest := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelRequest()
+
+	ctx, cancel := MergeContexts(context.Background(), request)
+	defer cancel()
+
+	child, cancelChild := context.WithCancel(ctx)
+	defer cancelChild()
+
+	<-child.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected merged context.DeadlineExceeded, got %v", ctx.Err())
+	}
+	if !errors.Is(child.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected child context.DeadlineExceeded, got %v", child.Err())
+	}
+}
+
+// TestMergeContextsAlreadyDone memastikan parent yang sudah selesai langsung berlaku
+func TestMergeContextsAlreadyDone(t *testing.T) {
+	done, cancelDone := context.WithCancel(context.Background())
+	cancelDone()
+
+	ctx, cancel := MergeContexts(context.Background(), done)
+	defer cancel()
+
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", ctx.Err())
+	}
+}
+
+// TestMergeContextsNoLeak memastikan cancel melepas semua pendaftaran
+// sehingga tidak ada goroutine yang tertinggal
+func TestMergeContextsNoLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	for i := 0; i < 100; i++ {
+		_, cancel := MergeContexts(parent, context.Background())
+		cancel()
+	}
+	cancelParent()
+
+	leaktest.WaitGoroutines(t, before)
+}
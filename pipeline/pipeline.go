@@ -0,0 +1,132 @@
+// Package pipeline menyediakan tahapan pipeline generik berbasis channel
+// (generate, map, fan-in, collect) yang berhenti ketika context selesai.
+// Setiap tahapan menutup channel output-nya sendiri dan tidak pernah
+// tertahan saat mengirim, sehingga pembatalan di tengah pipeline
+// tidak meninggalkan goroutine.
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// send mengirim value ke out, atau mengembalikan false jika ctx selesai lebih dulu
+func send[T any](ctx context.Context, out chan<- T, value T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case out <- value:
+		return true
+	}
+}
+
+// Generate mengirim values satu per satu ke channel output
+func Generate[T any](ctx context.Context, values ...T) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		for _, value := range values {
+			if !send(ctx, out, value) {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// Map menerapkan fn pada setiap nilai dari in menggunakan sejumlah
+// parallelism goroutine. Dengan parallelism lebih dari 1, urutan
+// output tidak dijamin sama dengan urutan input.
+// Best practice: fn sebaiknya ikut memeriksa ctx jika prosesnya lama
+func Map[T, U any](ctx context.Context, in <-chan T, fn func(ctx context.Context, value T) U, parallelism int) <-chan U {
+	out := make(chan U)
+	parallelism = max(parallelism, 1)
+
+	var wg sync.WaitGroup
+	wg.Add(parallelism)
+	for i := 0; i < parallelism; i++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case value, ok := <-in:
+					if !ok {
+						return
+					}
+					if !send(ctx, out, fn(ctx, value)) {
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	// Channel output ditutup setelah semua worker selesai
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// FanIn menggabungkan beberapa channel menjadi satu channel output.
+// Output ditutup setelah semua channel input ditutup atau ctx selesai
+func FanIn[T any](ctx context.Context, chans ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+	for _, in := range chans {
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case value, ok := <-in:
+					if !ok {
+						return
+					}
+					if !send(ctx, out, value) {
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// Collect membaca semua nilai dari in sampai channel ditutup.
+// Jika ctx selesai, Collect mengembalikan hasil parsial yang sudah terkumpul
+// beserta error context, termasuk ketika in sudah ditutup oleh tahapan
+// sebelumnya karena pembatalan yang sama
+func Collect[T any](ctx context.Context, in <-chan T) ([]T, error) {
+	var values []T
+
+	for {
+		select {
+		case <-ctx.Done():
+			return values, ctx.Err()
+		case value, ok := <-in:
+			if !ok {
+				return values, ctx.Err()
+			}
+			values = append(values, value)
+		}
+	}
+}
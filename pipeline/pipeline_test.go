@@ -0,0 +1,77 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"testing"
+
+	"belajar-golang-context/internal/leaktest"
+)
+
+func square(ctx context.Context, n int) int {
+	return n * n
+}
+
+// TestPipeline menjalankan generate → map → fan-in → collect sampai selesai
+func TestPipeline(t *testing.T) {
+	before := runtime.NumGoroutine()
+	fmt.Println("Total Goroutine", before)
+
+	ctx := context.Background()
+	odd := Map(ctx, Generate(ctx, 1, 3, 5), square, 2)
+	even := Map(ctx, Generate(ctx, 2, 4, 6), square, 2)
+
+	values, err := Collect(ctx, FanIn(ctx, odd, even))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slices.Sort(values)
+	if fmt.Sprint(values) != "[1 4 9 16 25 36]" {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	leaktest.WaitGoroutines(t, before)
+}
+
+// TestPipelineCancel membatalkan context di tengah pipeline dan memastikan
+// hasil parsial dikembalikan tanpa meninggalkan goroutine
+func TestPipelineCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+	fmt.Println("Total Goroutine", before)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	numbers := make([]int, 100)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+
+	// Stage map membatalkan pipeline setelah memproses angka 10
+	slow := Map(ctx, Generate(ctx, numbers...), func(ctx context.Context, n int) int {
+		if n == 10 {
+			cancel()
+		}
+		return n
+	}, 1)
+
+	values, err := Collect(ctx, FanIn(ctx, slow))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if len(values) > 10 {
+		t.Errorf("expected partial results, got %v", values)
+	}
+	for i, n := range values {
+		if n != i+1 {
+			t.Errorf("expected values in order, got %v", values)
+			break
+		}
+	}
+
+	leaktest.WaitGoroutines(t, before)
+}
@@ -0,0 +1,127 @@
+// Package ratelimit menyediakan token bucket yang menghormati context,
+// sebagai pengganti time.Sleep untuk membatasi laju producer.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter adalah token bucket yang mengisi satu token setiap interval,
+// dengan kapasitas maksimum burst token. Bucket dimulai dalam keadaan penuh
+// sehingga burst pemanggilan pertama tidak perlu menunggu.
+// Aman digunakan oleh banyak goroutine sekaligus
+type Limiter struct {
+	interval time.Duration
+	burst    int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter membuat limiter dengan satu token per interval.
+// Interval 0 atau negatif berarti tanpa batas. Burst minimal 1
+func NewLimiter(interval time.Duration, burst int) *Limiter {
+	burst = max(burst, 1)
+	return &Limiter{
+		interval: interval,
+		burst:    burst,
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait menunggu sampai satu token tersedia.
+// Jika ctx selesai lebih dulu, Wait langsung kembali dengan error context
+// dan token yang sudah dipesan dikembalikan ke bucket
+func (limiter *Limiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if limiter.interval <= 0 {
+		return nil
+	}
+
+	wait := limiter.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	// Best practice: Gunakan timer + select, bukan time.Sleep,
+	// agar penantian bisa dihentikan oleh context
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		limiter.release()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve mengambil satu token dan mengembalikan berapa lama pemanggil
+// harus menunggu sampai token itu benar-benar tersedia
+func (limiter *Limiter) reserve() time.Duration {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := time.Now()
+	limiter.tokens += float64(now.Sub(limiter.last)) / float64(limiter.interval)
+	limiter.tokens = min(limiter.tokens, float64(limiter.burst))
+	limiter.last = now
+
+	limiter.tokens--
+	if limiter.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-limiter.tokens * float64(limiter.interval))
+}
+
+// release mengembalikan token yang dipesan tetapi tidak jadi dipakai
+func (limiter *Limiter) release() {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	limiter.tokens = min(limiter.tokens+1, float64(limiter.burst))
+}
+
+// Throttle meneruskan nilai dari in ke channel output dengan laju paling cepat
+// satu nilai per interval. Channel output ditutup ketika in ditutup atau ctx selesai
+func Throttle[T any](ctx context.Context, in <-chan T, interval time.Duration) <-chan T {
+	out := make(chan T)
+	limiter := NewLimiter(interval, 1)
+
+	go func() {
+		defer close(out)
+
+		for {
+			var value T
+			var ok bool
+
+			select {
+			case <-ctx.Done():
+				return
+			case value, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+
+			if err := limiter.Wait(ctx); err != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- value:
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"belajar-golang-context/internal/leaktest"
+)
+
+// TestLimiterWait memastikan burst pertama langsung lolos
+// dan pemanggilan berikutnya mengikuti interval
+func TestLimiterWait(t *testing.T) {
+	// start diambil sebelum limiter dibuat, sehingga total penantian
+	// tidak mungkin kurang dari 3 x 20ms
+	start := time.Now()
+	limiter := NewLimiter(20*time.Millisecond, 2)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 2 token dari burst, 3 token berikutnya menunggu 3 x 20ms
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("expected at least 60ms, got %v", elapsed)
+	}
+}
+
+// TestLimiterWaitCanceled memastikan Wait kembali segera saat context dibatalkan
+func TestLimiterWaitCanceled(t *testing.T) {
+	limiter := NewLimiter(time.Hour, 1)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected early return, took %v", elapsed)
+	}
+}
+
+// TestThrottle memastikan semua nilai diteruskan sesuai urutan dengan laju terbatas
+func TestThrottle(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 1; i <= 4; i++ {
+			in <- i
+		}
+	}()
+
+	start := time.Now()
+	var values []int
+	for n := range Throttle(context.Background(), in, 10*time.Millisecond) {
+		values = append(values, n)
+	}
+
+	if len(values) != 4 || values[0] != 1 || values[3] != 4 {
+		t.Errorf("unexpected values %v", values)
+	}
+	if elapsed := time.Since(start); elapsed < 25*time.Millisecond {
+		t.Errorf("expected throttled output, took %v", elapsed)
+	}
+}
+
+// TestThrottleCancel memastikan goroutine Throttle keluar saat context dibatalkan
+func TestThrottleCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := Throttle(ctx, in, time.Hour)
+
+	cancel()
+	for range out {
+	}
+
+	leaktest.WaitGoroutines(t, before)
+}
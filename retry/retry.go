@@ -0,0 +1,123 @@
+// Package retry menjalankan ulang operasi dengan backoff
+// sambil menghormati pembatalan dan deadline context.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// ErrAttemptsExhausted dikembalikan (dalam bentuk wrapped error bersama
+// error terakhir) ketika semua percobaan gagal
+var ErrAttemptsExhausted = errors.New("retry: percobaan habis")
+
+type config struct {
+	maxAttempts int
+	initial     time.Duration
+	factor      float64
+	jitter      bool
+}
+
+// Option mengatur perilaku Do
+type Option func(*config)
+
+// WithMaxAttempts menentukan jumlah percobaan maksimum, termasuk percobaan pertama.
+// Default 3
+func WithMaxAttempts(n int) Option {
+	return func(c *config) {
+		c.maxAttempts = n
+	}
+}
+
+// WithExponentialBackoff menentukan jeda sebelum percobaan kedua (initial)
+// dan pengali jeda untuk setiap percobaan berikutnya (factor).
+// Default 100ms dengan pengali 2
+func WithExponentialBackoff(initial time.Duration, factor float64) Option {
+	return func(c *config) {
+		c.initial = initial
+		c.factor = factor
+	}
+}
+
+// WithJitter mengacak setiap jeda antara 50% dan 100% dari nilainya,
+// agar banyak client yang gagal bersamaan tidak mencoba ulang bersamaan
+func WithJitter() Option {
+	return func(c *config) {
+		c.jitter = true
+	}
+}
+
+// Do menjalankan fn sampai berhasil atau percobaan habis.
+// Do berhenti lebih awal jika ctx dibatalkan, atau jika sisa deadline ctx
+// lebih pendek dari jeda berikutnya, karena percobaan berikutnya pasti
+// tidak sempat dijalankan. Error yang dikembalikan bisa diperiksa dengan
+// errors.Is terhadap ErrAttemptsExhausted, context.Canceled, atau
+// context.DeadlineExceeded, dan selalu membungkus error terakhir dari fn
+func Do(ctx context.Context, fn func(ctx context.Context) error, options ...Option) error {
+	c := config{maxAttempts: 3, initial: 100 * time.Millisecond, factor: 2}
+	for _, option := range options {
+		option(&c)
+	}
+
+	delay := max(c.initial, 0)
+	var lastErr error
+
+	for attempt := 1; ; attempt++ {
+		// Best practice: Periksa context sebelum setiap percobaan
+		if err := ctx.Err(); err != nil {
+			return stopped(attempt-1, err, lastErr)
+		}
+
+		lastErr = fn(ctx)
+		if lastErr == nil {
+			return nil
+		}
+
+		if attempt >= c.maxAttempts {
+			return fmt.Errorf("%w setelah %d percobaan: %w", ErrAttemptsExhausted, attempt, lastErr)
+		}
+
+		wait := delay
+		if c.jitter {
+			wait = wait/2 + rand.N(wait/2+1)
+		}
+		delay = nextDelay(delay, c.factor)
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return fmt.Errorf("retry: sisa deadline lebih pendek dari jeda %s setelah %d percobaan: %w (error terakhir: %w)",
+				wait, attempt, context.DeadlineExceeded, lastErr)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return stopped(attempt, ctx.Err(), lastErr)
+		case <-timer.C:
+		}
+	}
+}
+
+func stopped(attempts int, ctxErr, lastErr error) error {
+	if lastErr == nil {
+		return fmt.Errorf("retry: dihentikan sebelum percobaan pertama: %w", ctxErr)
+	}
+	return fmt.Errorf("retry: dihentikan setelah %d percobaan: %w (error terakhir: %w)", attempts, ctxErr, lastErr)
+}
+
+// nextDelay mengalikan delay dengan factor, dibatasi antara 0 dan durasi
+// maksimum agar pengali besar tidak membuat time.Duration overflow
+func nextDelay(delay time.Duration, factor float64) time.Duration {
+	next := float64(delay) * factor
+	switch {
+	case next >= math.MaxInt64:
+		return math.MaxInt64
+	case next <= 0 || math.IsNaN(next):
+		return 0
+	}
+	return time.Duration(next)
+}
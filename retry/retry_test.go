@@ -0,0 +1,136 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+var errTemporary = errors.New("gagal sementara")
+
+// TestDoSucceeds memastikan Do berhenti mencoba setelah fn berhasil
+func TestDoSucceeds(t *testing.T) {
+	attempts := 0
+	err := Do(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errTemporary
+		}
+		return nil
+	}, WithMaxAttempts(5), WithExponentialBackoff(time.Millisecond, 2), WithJitter())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+// TestDoAttemptsExhausted memastikan error membedakan percobaan habis
+// dari error context dan tetap membungkus error terakhir
+func TestDoAttemptsExhausted(t *testing.T) {
+	attempts := 0
+	err := Do(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return errTemporary
+	}, WithMaxAttempts(4), WithExponentialBackoff(time.Millisecond, 2))
+
+	if attempts != 4 {
+		t.Errorf("expected 4 attempts, got %d", attempts)
+	}
+	if !errors.Is(err, ErrAttemptsExhausted) || !errors.Is(err, errTemporary) {
+		t.Errorf("expected ErrAttemptsExhausted wrapping last error, got %v", err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("unexpected context.DeadlineExceeded in %v", err)
+	}
+}
+
+// TestDoDeadlineShorterThanBackoff memastikan Do tidak menunggu jeda
+// yang pasti melewati deadline
+func TestDoDeadlineShorterThanBackoff(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	attempts := 0
+	err := Do(ctx, func(ctx context.Context) error {
+		attempts++
+		return errTemporary
+	}, WithMaxAttempts(5), WithExponentialBackoff(time.Second, 2))
+
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected immediate abort, took %v", elapsed)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrAttemptsExhausted) {
+		t.Errorf("expected context.DeadlineExceeded only, got %v", err)
+	}
+	if !errors.Is(err, errTemporary) {
+		t.Errorf("expected last error to be wrapped, got %v", err)
+	}
+}
+
+// TestDoCanceledDuringBackoff memastikan pembatalan saat menunggu jeda
+// langsung menghentikan Do
+func TestDoCanceledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := Do(ctx, func(ctx context.Context) error {
+		return errTemporary
+	}, WithExponentialBackoff(time.Second, 2))
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected early return on cancel, took %v", elapsed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+// TestDoAlreadyCanceled memastikan fn tidak dijalankan jika context sudah selesai
+func TestDoAlreadyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Do(ctx, func(ctx context.Context) error {
+		t.Error("fn must not be called")
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+// TestDoNegativeBackoffWithJitter memastikan backoff negatif dianggap 0
+// dan tidak membuat jitter panic
+func TestDoNegativeBackoffWithJitter(t *testing.T) {
+	err := Do(context.Background(), func(ctx context.Context) error {
+		return errTemporary
+	}, WithExponentialBackoff(-time.Second, 2), WithJitter())
+
+	if !errors.Is(err, ErrAttemptsExhausted) {
+		t.Errorf("expected ErrAttemptsExhausted, got %v", err)
+	}
+}
+
+// TestNextDelaySaturates memastikan pengali besar tidak membuat delay overflow
+func TestNextDelaySaturates(t *testing.T) {
+	delay := time.Second
+	for i := 0; i < 100; i++ {
+		delay = nextDelay(delay, 1000)
+		if delay < 0 {
+			t.Fatalf("delay overflowed to %v after %d steps", delay, i+1)
+		}
+	}
+	if delay != math.MaxInt64 {
+		t.Errorf("expected saturated delay, got %v", delay)
+	}
+}
@@ -0,0 +1,63 @@
+// Package signalcontext menyediakan root context yang dibatalkan oleh sinyal OS
+// dan runner graceful shutdown untuk program CLI maupun server.
+package signalcontext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// Hook adalah fungsi cleanup yang dijalankan saat shutdown.
+// ctx yang diterima memiliki deadline per-hook dari GracefulShutdown
+type Hook func(ctx context.Context) error
+
+// NotifyContext membuat root context yang dibatalkan ketika salah satu sinyal
+// diterima, misalnya os.Interrupt atau syscall.SIGTERM.
+// Best practice: Panggil stop dengan defer agar handler sinyal dilepas
+// dan sinyal berikutnya kembali menghentikan program seperti biasa
+func NotifyContext(signals ...os.Signal) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), signals...)
+}
+
+// GracefulShutdown menunggu ctx selesai, lalu menjalankan hooks dalam urutan
+// terbalik (hook yang didaftarkan terakhir dijalankan pertama), masing-masing
+// dengan batas waktu timeout. Context hook mewarisi nilai dari ctx tetapi
+// tidak ikut dibatalkan olehnya, karena ctx justru sudah selesai saat shutdown.
+// Hook yang melewati batas waktu dilewati agar hook berikutnya tetap berjalan.
+// Semua error hook digabung menjadi satu error.
+// Note: Goroutine hook yang mengabaikan ctx tetap berjalan sampai hook itu selesai
+func GracefulShutdown(ctx context.Context, timeout time.Duration, hooks ...Hook) error {
+	<-ctx.Done()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := runHook(ctx, timeout, hooks[i]); err != nil {
+			errs = append(errs, fmt.Errorf("signalcontext: hook %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func runHook(parent context.Context, timeout time.Duration, hook Hook) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(parent), timeout)
+	defer cancel()
+
+	// Channel buffered agar goroutine hook tidak tertahan
+	// ketika hasilnya tidak lagi ditunggu
+	result := make(chan error, 1)
+	go func() {
+		result <- hook(ctx)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
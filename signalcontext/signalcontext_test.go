@@ -0,0 +1,87 @@
+package signalcontext
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestNotifyContext memastikan root context dibatalkan ketika sinyal diterima
+func TestNotifyContext(t *testing.T) {
+	ctx, stop := NotifyContext(os.Interrupt)
+	defer stop()
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Skip("sending os.Interrupt is not supported:", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected context to be canceled by signal")
+	}
+}
+
+// TestGracefulShutdownOrder memastikan hook dijalankan dalam urutan terbalik
+// dengan context yang tetap membawa nilai dari root context
+func TestGracefulShutdownOrder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), "app", "demo"))
+	cancel()
+
+	var order []string
+	hook := func(name string) Hook {
+		return func(ctx context.Context) error {
+			if ctx.Value("app") != "demo" {
+				t.Errorf("hook %s: expected value from root context", name)
+			}
+			if ctx.Err() != nil {
+				t.Errorf("hook %s: expected live context, got %v", name, ctx.Err())
+			}
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	err := GracefulShutdown(ctx, time.Second, hook("database"), hook("cache"), hook("http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(order, []string{"http", "cache", "database"}) {
+		t.Errorf("unexpected order %v", order)
+	}
+}
+
+// TestGracefulShutdownHookTimeout memastikan hook yang terlalu lama dibatasi
+// deadline-nya sendiri dan tidak menghalangi hook berikutnya
+func TestGracefulShutdownHookTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	failure := errors.New("gagal")
+	var ran bool
+
+	err := GracefulShutdown(ctx, 50*time.Millisecond,
+		func(ctx context.Context) error {
+			ran = true
+			return failure
+		},
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	)
+
+	if !ran {
+		t.Error("expected first hook to run after the slow one timed out")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, failure) {
+		t.Errorf("expected both hook errors, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Package taskgroup menjalankan sekelompok goroutine yang saling terkait,
+// membatalkan semuanya ketika salah satu gagal, dan menunggu sampai
+// semua task selesai, sebagai pengganti pola time.Sleep
+// untuk menunggu cleanup.
+package taskgroup
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Task adalah pekerjaan dalam group. ctx dibatalkan ketika task lain
+// gagal, parent dibatalkan, atau timeout task tercapai
+type Task func(ctx context.Context) error
+
+// Group adalah kumpulan task yang berbagi satu context
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+
+	errOnce sync.Once
+	err     error
+}
+
+// WithContext membuat Group beserta context turunan dari parent.
+// Context tersebut dibatalkan pada error pertama dari task mana pun,
+// dengan error itu sebagai cause, atau ketika Wait selesai
+func WithContext(parent context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(parent)
+	return &Group{ctx: ctx, cancel: cancel}, ctx
+}
+
+// Go menjalankan task di goroutine baru
+func (group *Group) Go(task Task) {
+	group.wg.Add(1)
+
+	go func() {
+		defer group.wg.Done()
+
+		if err := task(group.ctx); err != nil {
+			group.errOnce.Do(func() {
+				group.err = err
+				group.cancel(err)
+			})
+		}
+	}()
+}
+
+// GoWithTimeout sama seperti Go, tetapi context task dibatasi timeout.
+// Task yang melewati timeout dan mengembalikan error context
+// dianggap gagal dan ikut membatalkan task lainnya
+func (group *Group) GoWithTimeout(timeout time.Duration, task Task) {
+	group.Go(func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return task(ctx)
+	})
+}
+
+// Wait menunggu semua task selesai lalu mengembalikan error pertama, jika ada.
+// Setelah Wait kembali, semua Task sudah return; goroutine pembungkusnya
+// hanya tinggal keluar dan tidak lagi menjalankan kode task.
+// Best practice: Selalu panggil Wait, termasuk saat parent dibatalkan,
+// agar resource context dilepas
+func (group *Group) Wait() error {
+	group.wg.Wait()
+	group.cancel(context.Canceled)
+	return group.err
+}
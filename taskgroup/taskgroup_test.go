@@ -0,0 +1,98 @@
+package taskgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"belajar-golang-context/internal/leaktest"
+)
+
+// TestGroupSuccess memastikan Wait menunggu semua task dan tidak ada
+// goroutine yang tertinggal, tanpa perlu time.Sleep
+func TestGroupSuccess(t *testing.T) {
+	before := runtime.NumGoroutine()
+	fmt.Println("Total Goroutine", before)
+
+	group, ctx := WithContext(context.Background())
+	results := make([]int, 5)
+	for i := range results {
+		group.Go(func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			results[i] = i * i
+			return nil
+		})
+	}
+	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+
+	if err := group.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(results) != "[0 1 4 9 16]" {
+		t.Errorf("unexpected results %v", results)
+	}
+	if ctx.Err() == nil {
+		t.Error("expected group context to be canceled after Wait")
+	}
+
+	leaktest.WaitGoroutines(t, before)
+}
+
+// TestGroupFirstError memastikan error pertama membatalkan semua task lain
+func TestGroupFirstError(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	failure := errors.New("gagal")
+	group, ctx := WithContext(context.Background())
+
+	for i := 0; i < 3; i++ {
+		group.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}
+	group.Go(func(ctx context.Context) error {
+		return failure
+	})
+
+	if err := group.Wait(); !errors.Is(err, failure) {
+		t.Errorf("expected first error, got %v", err)
+	}
+	if !errors.Is(context.Cause(ctx), failure) {
+		t.Errorf("expected cause to be first error, got %v", context.Cause(ctx))
+	}
+
+	leaktest.WaitGoroutines(t, before)
+}
+
+// TestGroupTaskTimeout memastikan timeout per-task membatalkan task tersebut
+// dan, karena dianggap gagal, juga task lainnya
+func TestGroupTaskTimeout(t *testing.T) {
+	group, _ := WithContext(context.Background())
+
+	group.GoWithTimeout(20*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	var siblingErr error
+	group.Go(func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			siblingErr = context.Cause(ctx)
+			return nil
+		case <-time.After(time.Second):
+			return errors.New("sibling was not canceled")
+		}
+	})
+
+	if err := group.Wait(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if !errors.Is(siblingErr, context.DeadlineExceeded) {
+		t.Errorf("expected sibling cause context.DeadlineExceeded, got %v", siblingErr)
+	}
+}
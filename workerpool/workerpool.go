@@ -0,0 +1,131 @@
+// Package workerpool menyediakan pool goroutine dengan jumlah tetap yang
+// seluruh lifecycle-nya dikendalikan oleh context.
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrPoolClosed dikembalikan Submit setelah Shutdown dipanggil
+var ErrPoolClosed = errors.New("workerpool: pool sudah ditutup")
+
+// Job adalah pekerjaan yang dijalankan oleh worker.
+// ctx akan dibatalkan ketika parent context pool dibatalkan
+// atau Shutdown melewati batas waktunya
+type Job func(ctx context.Context) error
+
+// Pool menjalankan Job menggunakan sejumlah worker goroutine yang tetap
+type Pool struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   chan Job
+	wg     sync.WaitGroup
+
+	// quit ditutup oleh Shutdown. Channel jobs sendiri tidak pernah ditutup,
+	// sehingga Submit bisa menunggu worker tanpa memegang lock apa pun
+	quit     chan struct{}
+	quitOnce sync.Once
+
+	errMu sync.Mutex
+	errs  []error
+}
+
+// New membuat pool dengan n worker yang berjalan di bawah parent.
+// Ketika parent dibatalkan, semua worker berhenti mengambil job baru
+// dan job yang sedang berjalan menerima sinyal pembatalan.
+// New panic jika n kurang dari 1, karena pool tanpa worker
+// akan membuat Submit menunggu selamanya
+func New(parent context.Context, n int) *Pool {
+	if n < 1 {
+		panic("workerpool: jumlah worker harus lebih dari 0")
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	pool := &Pool{
+		ctx:    ctx,
+		cancel: cancel,
+		jobs:   make(chan Job),
+		quit:   make(chan struct{}),
+	}
+
+	pool.wg.Add(n)
+	for i := 0; i < n; i++ {
+		go pool.work()
+	}
+
+	return pool
+}
+
+func (pool *Pool) work() {
+	defer pool.wg.Done()
+
+	for {
+		select {
+		case <-pool.ctx.Done():
+			return
+		case <-pool.quit:
+			return
+		case job := <-pool.jobs:
+			if err := job(pool.ctx); err != nil {
+				pool.errMu.Lock()
+				pool.errs = append(pool.errs, err)
+				pool.errMu.Unlock()
+			}
+		}
+	}
+}
+
+// Submit mengirim job ke worker yang sedang kosong, menunggu jika semua sibuk.
+// Mengembalikan ErrPoolClosed jika pool sudah di-Shutdown, atau error
+// context jika pool dibatalkan sebelum job diterima worker
+func (pool *Pool) Submit(job Job) error {
+	// Memeriksa quit lebih dulu agar Submit setelah Shutdown
+	// selalu ditolak, bukan diterima secara acak oleh select di bawah
+	select {
+	case <-pool.quit:
+		return ErrPoolClosed
+	default:
+	}
+
+	select {
+	case <-pool.ctx.Done():
+		return pool.ctx.Err()
+	case <-pool.quit:
+		return ErrPoolClosed
+	case pool.jobs <- job:
+		return nil
+	}
+}
+
+// Shutdown berhenti menerima job baru lalu menunggu job yang sedang berjalan selesai.
+// Jika ctx selesai lebih dulu, semua worker dibatalkan dan Shutdown tetap menunggu
+// mereka keluar sebelum mengembalikan error ctx.
+// Jika drain berhasil, Shutdown mengembalikan gabungan error dari semua job
+func (pool *Pool) Shutdown(ctx context.Context) error {
+	pool.quitOnce.Do(func() {
+		close(pool.quit)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		pool.wg.Wait()
+		close(done)
+	}()
+
+	// Best practice: Selalu lepaskan resource context setelah pool selesai
+	defer pool.cancel()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		pool.cancel()
+		<-done
+		return ctx.Err()
+	}
+
+	pool.errMu.Lock()
+	defer pool.errMu.Unlock()
+	return errors.Join(pool.errs...)
+}
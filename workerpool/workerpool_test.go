@@ -0,0 +1,167 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"belajar-golang-context/internal/leaktest"
+)
+
+// TestPoolShutdownDrains memastikan Shutdown menunggu semua job selesai
+func TestPoolShutdownDrains(t *testing.T) {
+	before := runtime.NumGoroutine()
+	fmt.Println("Total Goroutine", before)
+
+	pool := New(context.Background(), 4)
+	fmt.Println("Total Goroutine", runtime.NumGoroutine())
+
+	var completed atomic.Int32
+	for i := 0; i < 20; i++ {
+		err := pool.Submit(func(ctx context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			completed.Add(1)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if completed.Load() != 20 {
+		t.Errorf("expected 20 completed jobs, got %d", completed.Load())
+	}
+	if err := pool.Submit(func(ctx context.Context) error { return nil }); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+
+	leaktest.WaitGoroutines(t, before)
+}
+
+// TestPoolJobErrors memastikan error dari job dikembalikan oleh Shutdown
+func TestPoolJobErrors(t *testing.T) {
+	pool := New(context.Background(), 2)
+	failure := errors.New("gagal")
+
+	pool.Submit(func(ctx context.Context) error { return failure })
+	pool.Submit(func(ctx context.Context) error { return nil })
+
+	if err := pool.Shutdown(context.Background()); !errors.Is(err, failure) {
+		t.Errorf("expected job error, got %v", err)
+	}
+}
+
+// TestPoolParentCancel memastikan pembatalan parent menghentikan semua worker
+// dan membatalkan job yang sedang berjalan
+func TestPoolParentCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	parent, cancel := context.WithCancel(context.Background())
+	pool := New(parent, 3)
+
+	started := make(chan struct{})
+	pool.Submit(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-started
+
+	cancel()
+
+	if err := pool.Submit(func(ctx context.Context) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if err := pool.Shutdown(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected canceled job error, got %v", err)
+	}
+
+	leaktest.WaitGoroutines(t, before)
+}
+
+// TestPoolShutdownTimeout memastikan Shutdown membatalkan job yang terlalu lama
+func TestPoolShutdownTimeout(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	pool := New(context.Background(), 1)
+	pool.Submit(func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Second):
+			return nil
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := pool.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	leaktest.WaitGoroutines(t, before)
+}
+
+// TestPoolShutdownWithPendingSubmit memastikan Submit yang sedang menunggu
+// worker tidak menghalangi Shutdown menghormati deadline-nya
+func TestPoolShutdownWithPendingSubmit(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	pool := New(context.Background(), 1)
+
+	started := make(chan struct{})
+	pool.Submit(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-started
+
+	// Satu-satunya worker sedang sibuk, sehingga Submit ini menunggu
+	pending := make(chan error, 1)
+	go func() {
+		pending <- pool.Submit(func(ctx context.Context) error { return nil })
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- pool.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-shutdown:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Shutdown ignored its deadline while a Submit was pending")
+	}
+
+	if err := <-pending; !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("expected pending Submit to get ErrPoolClosed, got %v", err)
+	}
+
+	leaktest.WaitGoroutines(t, before)
+}
+
+// TestNewRejectsNoWorkers memastikan pool tanpa worker ditolak
+func TestNewRejectsNoWorkers(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected New to panic for n <= 0")
+		}
+	}()
+	New(context.Background(), 0)
+}